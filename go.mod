@@ -1,3 +1,3 @@
 module github.com/adonovan/generics
 
-go 1.15
+go 1.19
@@ -15,5 +15,24 @@ func RuntimeHash(key interface{}, seed uintptr) uintptr {
 	return typehash(e.t, e.v, seed)
 }
 
+// FixedSeedHash returns a 64-bit hash of key computed with a fixed seed,
+// or panics if key is unhashable, just like RuntimeHash.
+//
+// The result for a given value is the same for every call within a
+// single process, but it is not reproducible across runs: the runtime
+// randomizes its hash functions at startup, so no hash built on them
+// can be stable across processes. Use a hash of an explicit encoding
+// of the key (e.g. hash/fnv) for that.
+func FixedSeedHash(key interface{}) uint64 {
+	h := uint64(RuntimeHash(key, fixedSeed))
+	if unsafe.Sizeof(uintptr(0)) < 8 {
+		// On 32-bit platforms, use a second seed to compute the high word.
+		h = uint64(RuntimeHash(key, fixedSeed^1))<<32 | h
+	}
+	return h
+}
+
+const fixedSeed = uintptr(0x9e3779b9) // arbitrary
+
 //go:linkname typehash reflect.typehash
 func typehash(t, p unsafe.Pointer, h uintptr) uintptr
@@ -0,0 +1,87 @@
+// Demonstration of the hash package.
+package main
+
+import (
+	"fmt"
+
+	"github.com/adonovan/generics/hash"
+)
+
+const seed = 123
+
+func main() {
+	fmt.Println(hash.Combine(seed, "a", 1) == hash.Combine(seed, "a", 1))     // true
+	fmt.Println(hash.Combine(seed, "a", "b") != hash.Combine(seed, "b", "a")) // true (order matters)
+	fmt.Println(hash.Combine(seed, 0, 0) != hash.Combine(seed, 0))            // true
+	fmt.Println(hash.Combine(seed, "a", 1) != hash.Combine(seed+1, "a", 1))   // true
+
+	// Keys that hash to zero, such as nil, still count, even with seed 0.
+	fmt.Println(hash.Combine(0, nil, 1) != hash.Combine(0, 1)) // true
+	fmt.Println(hash.Combine(0, nil) != hash.Combine(0))       // true
+
+	// No collisions among a few thousand small tuples.
+	seen := make(map[uintptr]bool)
+	for i := 0; i < 100; i++ {
+		for j := 0; j < 100; j++ {
+			seen[hash.Combine(seed, i, j)] = true
+		}
+	}
+	fmt.Println(len(seen)) // 10000
+
+	// A table keyed by tuples, without a struct type. Entries are
+	// bucketed by hash, but lookups compare the tuples themselves,
+	// so a hash collision cannot cause a wrong hit.
+	var t table
+	t.set("alice", "user", 42, true)
+	t.set("bob", "user", 43, true)
+	fmt.Println(t.get("user", 42, true))  // alice true
+	fmt.Println(t.get("user", 42, false)) //  false
+
+	defer func() { fmt.Println(recover()) }() // runtime error: hash of unhashable type []int
+	hash.Combine(seed, 1, []int{})
+}
+
+// A table is a map from tuples of hashable values to strings.
+type table struct {
+	buckets map[uintptr][]entry
+}
+
+type entry struct {
+	tuple []interface{}
+	value string
+}
+
+func (t *table) set(value string, tuple ...interface{}) {
+	if t.buckets == nil {
+		t.buckets = make(map[uintptr][]entry)
+	}
+	h := hash.Combine(seed, tuple...)
+	for i, e := range t.buckets[h] {
+		if equal(e.tuple, tuple) {
+			t.buckets[h][i].value = value
+			return
+		}
+	}
+	t.buckets[h] = append(t.buckets[h], entry{tuple, value})
+}
+
+func (t *table) get(tuple ...interface{}) (string, bool) {
+	for _, e := range t.buckets[hash.Combine(seed, tuple...)] {
+		if equal(e.tuple, tuple) {
+			return e.value, true
+		}
+	}
+	return "", false
+}
+
+func equal(x, y []interface{}) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+	return true
+}
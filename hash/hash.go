@@ -0,0 +1,41 @@
+// Package hash provides hash functions built on the runtime's map hash.
+// (It has no type parameters, so it is ordinary Go.)
+package hash // import "github.com/adonovan/generics/hash"
+
+import "github.com/adonovan/generics/hacks"
+
+// Combine returns a hash of the sequence of keys, computed by folding
+// the runtime hash of each key (see hacks.RuntimeHash) into an
+// accumulator using a multiply-xor mix. The order of keys matters:
+// in general Combine(seed, a, b) != Combine(seed, b, a).
+// It is useful for hashing a tuple of values, for example in a hash
+// table, without declaring a struct type for each combination.
+//
+// A hash is not a substitute for a key: distinct tuples may have the
+// same hash, so a table that buckets entries by hash must still
+// compare the tuples themselves to find an entry.
+//
+// Like the runtime hash, the result is not stable across processes,
+// and it is a function of each key's value but not its dynamic type,
+// so int(1) and int64(1) may hash alike. Distinct sequences collide
+// with a probability of roughly 2^-n for an n-bit uintptr; the hash
+// is not cryptographic and is not resistant to chosen inputs, though
+// each distinct seed gives rise to a different function.
+//
+// Combine panics if any key is dynamically unhashable (e.g. a slice,
+// or an interface holding one), just as would a map.
+func Combine(seed uintptr, keys ...interface{}) uintptr {
+	// Start from a non-zero state so that a key whose hash is zero
+	// (such as nil) still changes the result: each step multiplies.
+	h := uint64(seed) ^ offset
+	for _, k := range keys {
+		h = (h ^ uint64(hacks.RuntimeHash(k, seed))) * prime
+		h ^= h >> 32
+	}
+	return uintptr(h) // on 32-bit platforms, the low word, which depends on all bits
+}
+
+const (
+	offset = 0xcbf29ce484222325 // FNV-1 offset basis, an arbitrary non-zero value
+	prime  = 0x9e3779b97f4a7c15 // 2^64 / golden ratio, an odd number
+)
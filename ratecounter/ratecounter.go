@@ -0,0 +1,150 @@
+// A sliding-window event counter.
+// (It has no type parameters, so it is ordinary Go.)
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// A Counter counts events over a trailing window of time.
+// It divides time into buckets of a fixed resolution, and keeps the
+// counts of the most recent buckets in a ring; a bucket is reused,
+// and its old count discarded, when time has advanced by a full turn
+// of the ring. A finer resolution gives a more accurate rate at the
+// cost of more buckets.
+//
+// A Counter is concurrency-safe. Each operation holds a lock only
+// briefly, and Incr takes constant time.
+type Counter struct {
+	resolution time.Duration
+	now        func() time.Time // for testing
+
+	mu      sync.Mutex
+	buckets []bucket
+}
+
+type bucket struct {
+	tick  int64 // time of bucket, in units of resolution since the epoch
+	count int64
+}
+
+// New returns a new Counter that can report the rate over any window
+// up to span, using buckets of the specified resolution.
+// It panics if either duration is not positive.
+func New(resolution, span time.Duration) *Counter {
+	if resolution <= 0 || span <= 0 {
+		panic("ratecounter.New: durations must be positive")
+	}
+	n := (span + resolution - 1) / resolution
+	return &Counter{
+		resolution: resolution,
+		now:        time.Now,
+		buckets:    make([]bucket, n),
+	}
+}
+
+// Incr records n events at the current time.
+func (c *Counter) Incr(n int) {
+	tick := c.tick()
+	c.mu.Lock()
+	size := int64(len(c.buckets))
+	b := &c.buckets[(tick%size+size)%size] // tick may be negative
+	if b.tick != tick {
+		*b = bucket{tick: tick} // expire the old count
+	}
+	b.count += int64(n)
+	c.mu.Unlock()
+}
+
+// Rate returns the number of events recorded within the trailing
+// window ending now. The window is rounded up to a whole number of
+// buckets, including the current partial one, and is limited to the
+// span of the Counter. It returns zero for a non-positive window.
+func (c *Counter) Rate(window time.Duration) int64 {
+	if window <= 0 {
+		return 0
+	}
+	k := int64((window + c.resolution - 1) / c.resolution)
+	if n := int64(len(c.buckets)); k > n {
+		k = n
+	}
+	tick := c.tick()
+	var sum int64
+	c.mu.Lock()
+	for _, b := range c.buckets {
+		// Skip buckets that are expired (or, after a clock step, in the future).
+		if tick-k < b.tick && b.tick <= tick {
+			sum += b.count
+		}
+	}
+	c.mu.Unlock()
+	return sum
+}
+
+// tick returns the current bucket time.
+func (c *Counter) tick() int64 {
+	t, res := c.now().UnixNano(), int64(c.resolution)
+	if t < 0 {
+		return (t - res + 1) / res // round down, not toward zero
+	}
+	return t / res
+}
+
+// -- test --
+
+// Run with -race.
+func main() {
+	// Use a fake clock for determinism.
+	clock := time.Unix(1000, 0)
+	c := New(time.Second, time.Minute)
+	c.now = func() time.Time { return clock }
+	advance := func(d time.Duration) { clock = clock.Add(d) }
+
+	fmt.Println(c.Rate(time.Minute)) // 0
+	c.Incr(1)
+	c.Incr(2)
+	advance(10 * time.Second)
+	c.Incr(10)
+	fmt.Println(c.Rate(time.Second), c.Rate(5*time.Second), c.Rate(time.Minute)) // 10 10 13
+	fmt.Println(c.Rate(11*time.Second), c.Rate(time.Hour), c.Rate(0))            // 13 13 0
+
+	advance(55 * time.Second)                             // the first events fall out of the window
+	fmt.Println(c.Rate(time.Minute))                      // 10
+	c.Incr(100)                                           // reuses the bucket of the first events
+	fmt.Println(c.Rate(time.Second), c.Rate(time.Minute)) // 100 110
+
+	advance(time.Hour)               // all buckets expire, though none are overwritten
+	fmt.Println(c.Rate(time.Minute)) // 0
+
+	// Coarse resolution: fewer buckets, less accurate windows.
+	coarse := New(10*time.Second, time.Minute)
+	coarse.now = c.now
+	coarse.Incr(1)
+	advance(4 * time.Second)
+	fmt.Println(len(coarse.buckets), coarse.Rate(time.Second)) // 6 1 (same bucket)
+
+	// A clock before 1970 has negative ticks.
+	clock = time.Unix(-1000, 0)
+	c.Incr(7)
+	advance(500 * time.Millisecond)
+	c.Incr(1)
+	fmt.Println(c.Rate(time.Second), c.Rate(time.Minute)) // 8 8
+
+	// Concurrent use, with the real clock.
+	rc := New(time.Millisecond, time.Minute)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				rc.Incr(1)
+				rc.Rate(time.Second)
+			}
+		}()
+	}
+	wg.Wait()
+	fmt.Println(rc.Rate(time.Minute)) // 8000
+}